@@ -1,7 +1,8 @@
 package cdecl
 
-import "errors"
-
+// Call invokes the C function at addr using the cdecl calling convention,
+// passing up to six uintptr arguments in registers. Additional arguments
+// are spilled onto the stack by CallN.
 func Call(addr uintptr, a ...uintptr) (uintptr, error) {
 	switch l := len(a); l {
 	case 0:
@@ -19,6 +20,36 @@ func Call(addr uintptr, a ...uintptr) (uintptr, error) {
 	case 6:
 		return Call6(addr, a[0], a[1], a[2], a[3], a[4], a[5]), nil
 	default:
-		return 0, errors.New("too many arguments")
+		return CallN(addr, a), nil
+	}
+}
+
+// CallN invokes the C function at addr with an arbitrary number of uintptr
+// arguments. The first six arguments on amd64 (DI, SI, DX, CX, R8, R9) and
+// the first eight on arm64 (X0-X7) are passed in registers; the remainder
+// are spilled onto the stack, which callN keeps 16-byte aligned. The amd64
+// trampolines implement only the SysV register assignment: this package has
+// no Win64 variant with RCX/RDX/R8/R9 and a 32-byte shadow space.
+//
+// There is no hard limit on len(a); unlike Call, CallN never returns an
+// error for arity.
+func CallN(addr uintptr, a []uintptr) uintptr {
+	switch l := len(a); l {
+	case 0:
+		return Call0(addr)
+	case 1:
+		return Call1(addr, a[0])
+	case 2:
+		return Call2(addr, a[0], a[1])
+	case 3:
+		return Call3(addr, a[0], a[1], a[2])
+	case 4:
+		return Call4(addr, a[0], a[1], a[2], a[3])
+	case 5:
+		return Call5(addr, a[0], a[1], a[2], a[3], a[4])
+	case 6:
+		return Call6(addr, a[0], a[1], a[2], a[3], a[4], a[5])
+	default:
+		return callN(addr, a)
 	}
-}
\ No newline at end of file
+}
@@ -0,0 +1,8 @@
+package cdecl
+
+// SysV AMD64 register classes: integers/pointers in RDI, RSI, RDX, RCX, R8,
+// R9; floats in XMM0-XMM7.
+const (
+	maxIntRegs   = 6
+	maxFloatRegs = 8
+)
@@ -0,0 +1,7 @@
+package cdecl
+
+// AAPCS64 register classes: integers/pointers in X0-X7; floats in V0-V7.
+const (
+	maxIntRegs   = 8
+	maxFloatRegs = 8
+)
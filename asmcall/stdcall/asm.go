@@ -0,0 +1,30 @@
+//go:build windows
+
+package stdcall
+
+//go:noescape
+func Call0(addr uintptr) uintptr
+
+//go:noescape
+func Call1(addr uintptr, a0 uintptr) uintptr
+
+//go:noescape
+func Call2(addr uintptr, a0, a1 uintptr) uintptr
+
+//go:noescape
+func Call3(addr uintptr, a0, a1, a2 uintptr) uintptr
+
+//go:noescape
+func Call4(addr uintptr, a0, a1, a2, a3 uintptr) uintptr
+
+//go:noescape
+func Call5(addr uintptr, a0, a1, a2, a3, a4 uintptr) uintptr
+
+//go:noescape
+func Call6(addr uintptr, a0, a1, a2, a3, a4, a5 uintptr) uintptr
+
+// callN is the variadic-arity trampoline backing CallN. It is only called
+// for len(args) > 6; CallN dispatches smaller arities to the fixed Call*
+// funcs directly.
+//go:noescape
+func callN(addr uintptr, args []uintptr) uintptr
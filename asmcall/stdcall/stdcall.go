@@ -0,0 +1,58 @@
+//go:build windows
+
+package stdcall
+
+// Call invokes the C function at addr using the stdcall (Win64) calling
+// convention. On amd64 the first four arguments go in RCX, RDX, R8, R9 and
+// a fifth and sixth are placed on the stack above the 32-byte shadow space;
+// on arm64 up to six go in X0-X5. More than six arguments are passed by
+// CallN.
+func Call(addr uintptr, a ...uintptr) (uintptr, error) {
+	switch l := len(a); l {
+	case 0:
+		return Call0(addr), nil
+	case 1:
+		return Call1(addr, a[0]), nil
+	case 2:
+		return Call2(addr, a[0], a[1]), nil
+	case 3:
+		return Call3(addr, a[0], a[1], a[2]), nil
+	case 4:
+		return Call4(addr, a[0], a[1], a[2], a[3]), nil
+	case 5:
+		return Call5(addr, a[0], a[1], a[2], a[3], a[4]), nil
+	case 6:
+		return Call6(addr, a[0], a[1], a[2], a[3], a[4], a[5]), nil
+	default:
+		return CallN(addr, a), nil
+	}
+}
+
+// CallN invokes the C function at addr with an arbitrary number of uintptr
+// arguments. On amd64 the first four arguments are passed in RCX, RDX, R8,
+// R9 per Win64 and the remainder are spilled onto the stack after the
+// mandatory 32-byte shadow space, which callN keeps 16-byte aligned at the
+// call. On arm64 the first eight go in X0-X7 and the rest on the stack.
+//
+// There is no hard limit on len(a); unlike Call, CallN never returns an
+// error for arity.
+func CallN(addr uintptr, a []uintptr) uintptr {
+	switch l := len(a); l {
+	case 0:
+		return Call0(addr)
+	case 1:
+		return Call1(addr, a[0])
+	case 2:
+		return Call2(addr, a[0], a[1])
+	case 3:
+		return Call3(addr, a[0], a[1], a[2])
+	case 4:
+		return Call4(addr, a[0], a[1], a[2], a[3])
+	case 5:
+		return Call5(addr, a[0], a[1], a[2], a[3], a[4])
+	case 6:
+		return Call6(addr, a[0], a[1], a[2], a[3], a[4], a[5])
+	default:
+		return callN(addr, a)
+	}
+}
@@ -0,0 +1,43 @@
+package stdcall
+
+import (
+	"testing"
+
+	"github.com/lsegal/cppgo/internal/asmcall/asmcalltest"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCall(t *testing.T) {
+	v, e := Call(asmcalltest.GetF0Addr(), 0)
+	assert.NoError(t, e)
+	assert.Equal(t, uintptr(42), v)
+
+	v, e = Call(asmcalltest.GetF1Addr(), 0, 16)
+	assert.NoError(t, e)
+	assert.Equal(t, uintptr(17), v)
+
+	v, e = Call(asmcalltest.GetF2Addr(), 0, 4, 2)
+	assert.NoError(t, e)
+	assert.Equal(t, uintptr(2), v)
+
+	v, e = Call(asmcalltest.GetF3Addr(), 0, 4, 2, 2)
+	assert.NoError(t, e)
+	assert.Equal(t, uintptr(1), v)
+
+	v, e = Call(asmcalltest.GetF4Addr(), 0, 16, 2, 4, 2)
+	assert.NoError(t, e)
+	assert.Equal(t, uintptr(1), v)
+
+	v, e = Call(asmcalltest.GetF5Addr(), 0, 99, 99, 99, 99, 12)
+	assert.NoError(t, e)
+	assert.Equal(t, uintptr(12), v)
+}
+
+func TestCallNSpill(t *testing.T) {
+	// this + 7 args is more than Win64's 4-register stdcall window, so it
+	// forces CallN's stack-spill path rather than Call0-Call6.
+	got := CallN(asmcalltest.GetFSumAddr(), []uintptr{0, 1, 2, 3, 4, 5, 6, 7})
+	if want := uintptr(1 + 2 + 3 + 4 + 5 + 6 + 7); got != want {
+		t.Errorf("CallN = %d, want %d", got, want)
+	}
+}
@@ -0,0 +1,124 @@
+package stdcall
+
+import (
+	"fmt"
+	"math"
+	"unsafe"
+)
+
+// Kind identifies how an Arg or return value should be marshalled into the
+// Win64 calling convention: as an integer/pointer in a general-purpose
+// register, or as a float in an XMM register.
+type Kind int
+
+const (
+	Int Kind = iota
+	Float32
+	Float64
+	Ptr
+)
+
+// Arg is one argument to CallTyped. Build one with IntArg, PtrArg, F32Arg or
+// F64Arg. Floats are stored as their raw IEEE-754 bits, not converted, so a
+// Float32 arg ends up in the low 32 bits of its XMM register exactly as a C
+// `float` parameter expects.
+type Arg struct {
+	Kind Kind
+	i    uintptr
+	bits uint64
+}
+
+// IntArg returns an Arg carrying an integer/uintptr value.
+func IntArg(v uintptr) Arg { return Arg{Kind: Int, i: v} }
+
+// PtrArg returns an Arg carrying a pointer value.
+func PtrArg(v unsafe.Pointer) Arg { return Arg{Kind: Ptr, i: uintptr(v)} }
+
+// F32Arg returns an Arg carrying a float32 value, passed in an XMM register.
+func F32Arg(v float32) Arg { return Arg{Kind: Float32, bits: uint64(math.Float32bits(v))} }
+
+// F64Arg returns an Arg carrying a float64 value, passed in an XMM register.
+func F64Arg(v float64) Arg { return Arg{Kind: Float64, bits: math.Float64bits(v)} }
+
+// Ret is the typed result of a CallTyped, holding either an integer/pointer
+// value read from RAX or a float read from XMM0, depending on the Kind
+// passed to CallTyped.
+type Ret struct {
+	Kind Kind
+	i    uintptr
+	bits uint64
+}
+
+func (r Ret) Uintptr() uintptr { return r.i }
+func (r Ret) Float32() float32 { return math.Float32frombits(uint32(r.bits)) }
+func (r Ret) Float64() float64 { return math.Float64frombits(r.bits) }
+
+// maxSlots is the number of argument "slots" Win64 assigns positionally:
+// slot N is RCX/RDX/R8/R9 if the Nth argument is integral, or XMM0-XMM3 if
+// it is floating point — unlike SysV, the two register files share one
+// position counter instead of being numbered independently.
+const maxSlots = 4
+
+// CallTyped invokes the C function at addr, marshalling each Arg into the
+// register (or stack slot) its position in the argument list assigns it
+// under the Win64 convention, and spilling whatever overflows the first
+// four slots onto the stack (after the mandatory 32-byte shadow space) in
+// original order. retKind selects whether the result is read from RAX
+// (Int/Ptr) or XMM0 (Float32/Float64).
+//
+// CallTyped is windows/amd64 only: Win64 ARM64 assigns integer and float
+// arguments from independent register files rather than one shared slot
+// counter, so the trampoline below does not apply there. There is no
+// windows/arm64 implementation; Call and CallN remain available on that
+// platform.
+func CallTyped(addr uintptr, args []Arg, retKind Kind) (Ret, error) {
+	var slots [maxSlots]uint64
+	var isFloat [maxSlots]bool
+	nslots := 0
+	var stack []uintptr
+
+	for _, a := range args {
+		var bits uint64
+		switch a.Kind {
+		case Int, Ptr:
+			bits = uint64(a.i)
+		case Float32, Float64:
+			bits = a.bits
+		default:
+			return Ret{}, fmt.Errorf("stdcall: unknown arg kind %d", a.Kind)
+		}
+		if nslots < maxSlots {
+			slots[nslots] = bits
+			isFloat[nslots] = a.Kind == Float32 || a.Kind == Float64
+			nslots++
+		} else {
+			stack = append(stack, uintptr(bits))
+		}
+	}
+
+	var floatMask uint64
+	for i := 0; i < nslots; i++ {
+		if isFloat[i] {
+			floatMask |= 1 << uint(i)
+		}
+	}
+
+	var stackPtr *uintptr
+	if len(stack) > 0 {
+		stackPtr = &stack[0]
+	}
+
+	retI, retF := callTyped(addr, &slots[0], nslots, floatMask, stackPtr, len(stack))
+	if retKind == Float32 || retKind == Float64 {
+		return Ret{Kind: retKind, bits: retF}, nil
+	}
+	return Ret{Kind: retKind, i: retI}, nil
+}
+
+// callTyped is the trampoline backing CallTyped. slots holds the raw bits
+// of the first nslots arguments in position order; floatMask has bit i set
+// if slots[i] is a float that belongs in XMMi rather than its integer
+// register. stack holds whatever overflowed the four slots, in original
+// argument order.
+//go:noescape
+func callTyped(addr uintptr, slots *uint64, nslots int, floatMask uint64, stack *uintptr, nstack int) (retInt uintptr, retFloatBits uint64)
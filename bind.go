@@ -0,0 +1,179 @@
+// Package cppgo binds Go functions to C/C++ symbols loaded at runtime,
+// hiding the calling-convention plumbing of the asmcall packages behind
+// ordinary Go function calls.
+package cppgo
+
+import (
+	"fmt"
+	"reflect"
+	"unsafe"
+
+	"github.com/lsegal/cppgo/asmcall/cdecl"
+)
+
+var errType = reflect.TypeOf((*error)(nil)).Elem()
+
+// is32Bit is true when uintptr is narrower than the 64-bit values Bind may
+// need to marshal, in which case a single int64/uint64 argument is split
+// into two uintptr-sized Args.
+const is32Bit = unsafe.Sizeof(uintptr(0)) < 8
+
+// Bind populates fnPtr, a pointer to a Go function variable, with an
+// implementation that marshals each argument's reflect.Kind into a cdecl
+// Arg and invokes the C function at addr, unmarshalling the result back
+// into fnPtr's return type. This replaces the pattern of hand-casting every
+// argument to uintptr at each call site with one reflection-based adapter.
+//
+// fnPtr's signature may return at most two values: an optional typed result
+// (int/uint/float/pointer kind) followed by an optional trailing error,
+// which is only ever non-nil if an argument or return kind is unsupported.
+func Bind(fnPtr interface{}, addr uintptr) error {
+	v := reflect.ValueOf(fnPtr)
+	if v.Kind() != reflect.Ptr || v.Elem().Kind() != reflect.Func {
+		return fmt.Errorf("cppgo: Bind requires a pointer to a function, got %T", fnPtr)
+	}
+	fnType := v.Elem().Type()
+
+	returnsErr := fnType.NumOut() > 0 && fnType.Out(fnType.NumOut()-1) == errType
+	numResults := fnType.NumOut()
+	if returnsErr {
+		numResults--
+	}
+	if numResults > 1 {
+		return fmt.Errorf("cppgo: Bind signature may return at most one value plus error, got %d", fnType.NumOut())
+	}
+
+	var retKind cdecl.Kind
+	if numResults == 1 {
+		k, err := kindOf(fnType.Out(0))
+		if err != nil {
+			return fmt.Errorf("cppgo: Bind return type: %w", err)
+		}
+		retKind = k
+	}
+
+	impl := reflect.MakeFunc(fnType, func(in []reflect.Value) []reflect.Value {
+		args, err := marshalArgs(in)
+		if err != nil {
+			return bindResults(fnType, returnsErr, reflect.Value{}, err)
+		}
+
+		ret, err := cdecl.CallTyped(addr, args, retKind)
+		if err != nil {
+			return bindResults(fnType, returnsErr, reflect.Value{}, err)
+		}
+
+		var out reflect.Value
+		if numResults == 1 {
+			out, err = unmarshalRet(fnType.Out(0), ret)
+		}
+		return bindResults(fnType, returnsErr, out, err)
+	})
+
+	v.Elem().Set(impl)
+	return nil
+}
+
+// bindResults assembles the []reflect.Value MakeFunc must return, filling
+// the optional trailing error output from err.
+func bindResults(fnType reflect.Type, returnsErr bool, out reflect.Value, err error) []reflect.Value {
+	n := fnType.NumOut()
+	results := make([]reflect.Value, n)
+	i := 0
+	if out.IsValid() {
+		results[i] = out
+		i++
+	}
+	for ; i < n; i++ {
+		if returnsErr && i == n-1 {
+			if err != nil {
+				results[i] = reflect.ValueOf(&err).Elem()
+			} else {
+				results[i] = reflect.Zero(errType)
+			}
+			continue
+		}
+		results[i] = reflect.Zero(fnType.Out(i))
+	}
+	if err != nil && !returnsErr {
+		panic(err)
+	}
+	return results
+}
+
+func kindOf(t reflect.Type) (cdecl.Kind, error) {
+	switch t.Kind() {
+	case reflect.Float32:
+		return cdecl.Float32, nil
+	case reflect.Float64:
+		return cdecl.Float64, nil
+	case reflect.Ptr, reflect.UnsafePointer:
+		return cdecl.Ptr, nil
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return cdecl.Int, nil
+	default:
+		return 0, fmt.Errorf("unsupported kind %s", t.Kind())
+	}
+}
+
+// marshalArgs converts each reflect.Value into one or more cdecl.Args,
+// sign-extending small ints, taking the address of pointer/slice/string
+// data, and splitting 64-bit values into two Args on 32-bit builds.
+func marshalArgs(in []reflect.Value) ([]cdecl.Arg, error) {
+	args := make([]cdecl.Arg, 0, len(in))
+	for _, a := range in {
+		switch a.Kind() {
+		case reflect.Float32:
+			args = append(args, cdecl.F32Arg(float32(a.Float())))
+		case reflect.Float64:
+			args = append(args, cdecl.F64Arg(a.Float()))
+		case reflect.Ptr, reflect.UnsafePointer:
+			args = append(args, cdecl.PtrArg(unsafe.Pointer(a.Pointer())))
+		case reflect.Slice:
+			args = append(args, cdecl.PtrArg(unsafe.Pointer(a.Pointer())))
+		case reflect.String:
+			s := a.String()
+			hdr := (*reflect.StringHeader)(unsafe.Pointer(&s))
+			args = append(args, cdecl.PtrArg(unsafe.Pointer(hdr.Data)))
+		case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+			args = append(args, splitInt(uint64(a.Int()))...)
+		case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+			args = append(args, splitInt(a.Uint())...)
+		default:
+			return nil, fmt.Errorf("cppgo: unsupported argument kind %s", a.Kind())
+		}
+	}
+	return args, nil
+}
+
+// splitInt returns a single IntArg holding v, or on 32-bit builds two
+// IntArgs holding its low and high 32-bit halves in argument order.
+func splitInt(v uint64) []cdecl.Arg {
+	if !is32Bit {
+		return []cdecl.Arg{cdecl.IntArg(uintptr(v))}
+	}
+	return []cdecl.Arg{
+		cdecl.IntArg(uintptr(uint32(v))),
+		cdecl.IntArg(uintptr(uint32(v >> 32))),
+	}
+}
+
+func unmarshalRet(t reflect.Type, ret cdecl.Ret) (reflect.Value, error) {
+	switch t.Kind() {
+	case reflect.Float32:
+		return reflect.ValueOf(ret.Float32()).Convert(t), nil
+	case reflect.Float64:
+		return reflect.ValueOf(ret.Float64()).Convert(t), nil
+	case reflect.Ptr:
+		return reflect.NewAt(t.Elem(), unsafe.Pointer(ret.Uintptr())), nil
+	case reflect.UnsafePointer:
+		return reflect.ValueOf(unsafe.Pointer(ret.Uintptr())).Convert(t), nil
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return reflect.ValueOf(int64(ret.Uintptr())).Convert(t), nil
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return reflect.ValueOf(uint64(ret.Uintptr())).Convert(t), nil
+	default:
+		return reflect.Value{}, fmt.Errorf("cppgo: unsupported return kind %s", t.Kind())
+	}
+}
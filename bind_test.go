@@ -0,0 +1,30 @@
+package cppgo
+
+import (
+	"testing"
+	"unsafe"
+
+	"github.com/lsegal/cppgo/internal/asmcall/asmcalltest"
+)
+
+func TestBindInt(t *testing.T) {
+	var f1 func(this, a0 uintptr) uintptr
+	if err := Bind(&f1, asmcalltest.GetF1Addr()); err != nil {
+		t.Fatalf("Bind: %v", err)
+	}
+	if got, want := f1(0, 16), uintptr(17); got != want {
+		t.Errorf("f1(0, 16) = %d, want %d", got, want)
+	}
+}
+
+func TestBindUnsafePointer(t *testing.T) {
+	var identity func(p unsafe.Pointer) unsafe.Pointer
+	if err := Bind(&identity, asmcalltest.GetIdentityAddr()); err != nil {
+		t.Fatalf("Bind: %v", err)
+	}
+	var x int
+	p := unsafe.Pointer(&x)
+	if got := identity(p); got != p {
+		t.Errorf("identity(%p) = %p, want %p", p, got, p)
+	}
+}
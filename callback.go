@@ -0,0 +1,188 @@
+//go:build amd64
+
+package cppgo
+
+import (
+	"fmt"
+	"math"
+	"reflect"
+	"runtime"
+	"sync"
+	"unsafe"
+)
+
+// callbackEntry holds everything the shared landing pad needs to dispatch
+// one trampoline invocation back into a Go function.
+type callbackEntry struct {
+	fn    reflect.Value
+	in    []reflect.Type
+	outOK bool
+	out   reflect.Type
+}
+
+var (
+	callbackMu      sync.Mutex
+	callbackEntries []*callbackEntry
+)
+
+// callbackFrame is the layout the assembly landing pad fills in from the
+// incoming native registers (and reads the result back out of) before and
+// after calling dispatchFrame. Its field order and sizes are load-bearing:
+// the *_amd64.s landing pads index into it by byte offset.
+type callbackFrame struct {
+	id           uint32
+	_            uint32
+	ints         [6]uintptr
+	floats       [8]uint64
+	stack        *uintptr
+	retInt       uintptr
+	retFloatBits uint64
+}
+
+// NewCallback allocates an executable trampoline for fn and returns its
+// address as a raw C function pointer, usable anywhere a C++ API expects a
+// callback, comparator or event-sink function pointer (cdecl/SysV AMD64
+// only). fn's parameters and single optional return value may be of int,
+// uint, uintptr, float, pointer or unsafe.Pointer kind; unlike Bind, slice
+// and string parameters are not accepted. free releases the trampoline
+// page; the returned addr must not be called again afterwards.
+//
+// There is only one landing pad, callbackLandingSysV, so a trampoline is
+// only safe to hand to code that will call it with the SysV cdecl
+// convention. Passing one to a Win64 stdcall/thiscall API (a COM vtable
+// slot, for example) is not supported: the register layout the caller
+// would use does not match what the landing pad saves.
+func NewCallback(fn interface{}) (addr uintptr, free func()) {
+	v := reflect.ValueOf(fn)
+	if v.Kind() != reflect.Func {
+		panic(fmt.Sprintf("cppgo: NewCallback requires a function value, got %T", fn))
+	}
+	t := v.Type()
+	if t.NumOut() > 1 {
+		panic("cppgo: NewCallback functions may return at most one value")
+	}
+
+	entry := &callbackEntry{fn: v}
+	for i := 0; i < t.NumIn(); i++ {
+		entry.in = append(entry.in, t.In(i))
+	}
+	if t.NumOut() == 1 {
+		entry.outOK = true
+		entry.out = t.Out(0)
+	}
+
+	callbackMu.Lock()
+	id := uint32(len(callbackEntries))
+	callbackEntries = append(callbackEntries, entry)
+	callbackMu.Unlock()
+
+	page, err := allocExecPage(trampolineSize)
+	if err != nil {
+		panic(err)
+	}
+	writeTrampoline(page, id)
+	if err := protectExec(page); err != nil {
+		panic(err)
+	}
+
+	addr = uintptr(unsafe.Pointer(&page[0]))
+	freed := false
+	free = func() {
+		callbackMu.Lock()
+		if !freed {
+			callbackEntries[id] = nil
+			freed = true
+		}
+		callbackMu.Unlock()
+		freeExecPage(page)
+	}
+	return addr, free
+}
+
+// dispatchFrame is called (via ·dispatchFrame(SB)) by every landing pad
+// once it has saved the native argument registers and the address of any
+// stack-spilled arguments into f. It locks the OS thread for the duration
+// of the call, since re-entering the Go scheduler on a thread the C++ side
+// doesn't know about would be unsafe, then marshals f's fields into a
+// reflect.Call using the target function's recorded parameter types.
+func dispatchFrame(f *callbackFrame) {
+	runtime.LockOSThread()
+	defer runtime.UnlockOSThread()
+
+	callbackMu.Lock()
+	entry := callbackEntries[f.id]
+	callbackMu.Unlock()
+	if entry == nil {
+		panic("cppgo: callback invoked after its trampoline was freed")
+	}
+
+	nints, nfloats, nstack := 0, 0, 0
+	args := make([]reflect.Value, len(entry.in))
+	for i, pt := range entry.in {
+		switch pt.Kind() {
+		case reflect.Float32:
+			bits := nextFloat(f, &nfloats, &nstack)
+			args[i] = reflect.ValueOf(math.Float32frombits(uint32(bits))).Convert(pt)
+		case reflect.Float64:
+			bits := nextFloat(f, &nfloats, &nstack)
+			args[i] = reflect.ValueOf(math.Float64frombits(bits)).Convert(pt)
+		case reflect.Ptr:
+			v := nextInt(f, &nints, &nstack)
+			args[i] = reflect.NewAt(pt.Elem(), unsafe.Pointer(v)).Convert(pt)
+		case reflect.UnsafePointer:
+			v := nextInt(f, &nints, &nstack)
+			args[i] = reflect.ValueOf(unsafe.Pointer(v)).Convert(pt)
+		case reflect.Uintptr:
+			args[i] = reflect.ValueOf(nextInt(f, &nints, &nstack)).Convert(pt)
+		case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+			args[i] = reflect.ValueOf(int64(nextInt(f, &nints, &nstack))).Convert(pt)
+		case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+			args[i] = reflect.ValueOf(uint64(nextInt(f, &nints, &nstack))).Convert(pt)
+		default:
+			panic(fmt.Sprintf("cppgo: unsupported callback argument kind %s", pt.Kind()))
+		}
+	}
+
+	out := entry.fn.Call(args)
+	if !entry.outOK {
+		return
+	}
+	switch entry.out.Kind() {
+	case reflect.Float32:
+		f.retFloatBits = uint64(math.Float32bits(float32(out[0].Float())))
+	case reflect.Float64:
+		f.retFloatBits = math.Float64bits(out[0].Float())
+	case reflect.Ptr, reflect.UnsafePointer:
+		f.retInt = out[0].Pointer()
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		f.retInt = uintptr(out[0].Uint())
+	default:
+		f.retInt = uintptr(out[0].Int())
+	}
+}
+
+// nextInt and nextFloat pull the next argument out of the register banks
+// the landing pad saved, falling back to the spilled stack once the
+// relevant class's registers (6 integer, 8 float, on SysV AMD64) are
+// exhausted.
+func nextInt(f *callbackFrame, nints, nstack *int) uintptr {
+	if *nints < len(f.ints) {
+		v := f.ints[*nints]
+		*nints++
+		return v
+	}
+	v := *(*uintptr)(unsafe.Pointer(uintptr(unsafe.Pointer(f.stack)) + uintptr(*nstack)*unsafe.Sizeof(uintptr(0))))
+	*nstack++
+	return v
+}
+
+func nextFloat(f *callbackFrame, nfloats, nstack *int) uint64 {
+	if *nfloats < len(f.floats) {
+		v := f.floats[*nfloats]
+		*nfloats++
+		return v
+	}
+	v := *(*uint64)(unsafe.Pointer(uintptr(unsafe.Pointer(f.stack)) + uintptr(*nstack)*unsafe.Sizeof(uintptr(0))))
+	*nstack++
+	return v
+}
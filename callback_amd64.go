@@ -0,0 +1,34 @@
+package cppgo
+
+import (
+	"encoding/binary"
+	"reflect"
+)
+
+// trampolineSize is the length in bytes of the stub writeTrampoline emits.
+const trampolineSize = 19
+
+// writeTrampoline fills page with a tiny stub that loads id into R10 and
+// jumps to the shared landing pad, which looks the callback up by id:
+//
+//	MOV  R10D, id        ; 41 BA id32
+//	MOVABS R11, landing  ; 49 BB landing64
+//	JMP  R11             ; 41 FF E3
+func writeTrampoline(page []byte, id uint32) {
+	landing := uintptr(reflect.ValueOf(callbackLandingSysV).Pointer())
+
+	page[0], page[1] = 0x41, 0xBA
+	binary.LittleEndian.PutUint32(page[2:6], id)
+
+	page[6], page[7] = 0x49, 0xBB
+	binary.LittleEndian.PutUint64(page[8:16], uint64(landing))
+
+	page[16], page[17], page[18] = 0x41, 0xFF, 0xE3
+}
+
+// callbackLandingSysV is the shared SysV AMD64 entry point every
+// trampoline jumps to. It saves the incoming integer/XMM argument
+// registers and the caller's stack-spilled arguments into a callbackFrame,
+// calls dispatchFrame, and returns the result in RAX/XMM0 to whatever C++
+// code invoked the trampoline.
+func callbackLandingSysV()
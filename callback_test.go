@@ -0,0 +1,43 @@
+//go:build amd64
+
+package cppgo
+
+import (
+	"testing"
+	"unsafe"
+
+	"github.com/lsegal/cppgo/asmcall/cdecl"
+	"github.com/lsegal/cppgo/internal/asmcall/asmcalltest"
+)
+
+func TestNewCallback(t *testing.T) {
+	addr, free := NewCallback(func(v uintptr) uintptr { return v + 1 })
+	defer free()
+
+	got, err := cdecl.Call(asmcalltest.GetInvokeAddr(), addr, 41)
+	if err != nil {
+		t.Fatalf("Call: %v", err)
+	}
+	if want := uintptr(42); got != want {
+		t.Errorf("invoke(trampoline, 41) = %d, want %d", got, want)
+	}
+}
+
+type base100 struct{}
+
+func (base100) GetBase() uintptr { return 100 }
+
+func TestNewVTable(t *testing.T) {
+	vtable := NewVTable(base100{})
+	slot0 := *(*uintptr)(unsafe.Pointer(vtable))
+
+	// invoke's C signature ignores `this` on the callback side, matching a
+	// zero-argument method's adapter, which only takes the discarded `this`.
+	got, err := cdecl.Call(asmcalltest.GetInvokeAddr(), slot0, 0)
+	if err != nil {
+		t.Fatalf("Call: %v", err)
+	}
+	if want := uintptr(100); got != want {
+		t.Errorf("vtable slot 0 = %d, want %d", got, want)
+	}
+}
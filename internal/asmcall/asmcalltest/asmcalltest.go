@@ -0,0 +1,110 @@
+// Package asmcalltest provides small C test fixtures, built with cgo, so the
+// asmcall packages can be exercised against a real C ABI instead of
+// hand-computed register values. Every fixture takes a leading `this`
+// argument it ignores, so the same function works as both a plain cdecl
+// target (`this` is just an unused first argument) and a thiscall target
+// (`this` is the real receiver pointer, also unused by these fixtures).
+package asmcalltest
+
+/*
+#include <stdint.h>
+
+static uintptr_t f0(uintptr_t this) {
+	return 42;
+}
+
+static uintptr_t f1(uintptr_t this, uintptr_t a0) {
+	return a0 + 1;
+}
+
+static uintptr_t f2(uintptr_t this, uintptr_t a0, uintptr_t a1) {
+	return a0 - a1;
+}
+
+static uintptr_t f3(uintptr_t this, uintptr_t a0, uintptr_t a1, uintptr_t a2) {
+	return (a0 - a1) / a2;
+}
+
+static uintptr_t f4(uintptr_t this, uintptr_t a0, uintptr_t a1, uintptr_t a2, uintptr_t a3) {
+	return (a0 - a1) / a2 - a3;
+}
+
+static uintptr_t f5(uintptr_t this, uintptr_t a0, uintptr_t a1, uintptr_t a2, uintptr_t a3, uintptr_t a4) {
+	return a4;
+}
+
+// fsum takes eight arguments, more than the amd64 conventions this repo
+// supports pass in registers, so calling it there exercises the stack-spill
+// path in CallN; on arm64 all eight fit in X0-X7.
+static uintptr_t fsum(uintptr_t this, uintptr_t a0, uintptr_t a1, uintptr_t a2, uintptr_t a3, uintptr_t a4, uintptr_t a5, uintptr_t a6) {
+	return a0 + a1 + a2 + a3 + a4 + a5 + a6;
+}
+
+static double ffloat(double a, double b, double c) {
+	return a*b + c;
+}
+
+static void *fidentity(void *p) {
+	return p;
+}
+
+typedef uintptr_t (*callback)(uintptr_t);
+
+// invoke calls back into cb, the C-pointer-shaped signature every
+// cppgo.NewCallback trampoline and cppgo.NewVTable slot presents.
+static uintptr_t invoke(callback cb, uintptr_t arg) {
+	return cb(arg);
+}
+
+static void *addr_f0(void)      { return (void *)f0; }
+static void *addr_f1(void)      { return (void *)f1; }
+static void *addr_f2(void)      { return (void *)f2; }
+static void *addr_f3(void)      { return (void *)f3; }
+static void *addr_f4(void)      { return (void *)f4; }
+static void *addr_f5(void)      { return (void *)f5; }
+static void *addr_fsum(void)    { return (void *)fsum; }
+static void *addr_ffloat(void)  { return (void *)ffloat; }
+static void *addr_fidentity(void) { return (void *)fidentity; }
+static void *addr_invoke(void)  { return (void *)invoke; }
+*/
+import "C"
+import "unsafe"
+
+// GetF0Addr returns the address of a 0-arg function (plus the ignored
+// `this`) that always returns 42.
+func GetF0Addr() uintptr { return uintptr(unsafe.Pointer(C.addr_f0())) }
+
+// GetF1Addr returns the address of a 1-arg function returning a0+1.
+func GetF1Addr() uintptr { return uintptr(unsafe.Pointer(C.addr_f1())) }
+
+// GetF2Addr returns the address of a 2-arg function returning a0-a1.
+func GetF2Addr() uintptr { return uintptr(unsafe.Pointer(C.addr_f2())) }
+
+// GetF3Addr returns the address of a 3-arg function returning (a0-a1)/a2.
+func GetF3Addr() uintptr { return uintptr(unsafe.Pointer(C.addr_f3())) }
+
+// GetF4Addr returns the address of a 4-arg function returning
+// (a0-a1)/a2-a3.
+func GetF4Addr() uintptr { return uintptr(unsafe.Pointer(C.addr_f4())) }
+
+// GetF5Addr returns the address of a 5-arg function returning a4.
+func GetF5Addr() uintptr { return uintptr(unsafe.Pointer(C.addr_f5())) }
+
+// GetFSumAddr returns the address of a 7-arg function (plus `this`, for
+// eight total) returning the sum of a0..a6, forcing a stack spill on every
+// amd64 convention this repo implements (arm64 passes all eight in X0-X7).
+func GetFSumAddr() uintptr { return uintptr(unsafe.Pointer(C.addr_fsum())) }
+
+// GetFloatAddr returns the address of a 3-arg float64 function returning
+// a*b+c.
+func GetFloatAddr() uintptr { return uintptr(unsafe.Pointer(C.addr_ffloat())) }
+
+// GetIdentityAddr returns the address of a 1-arg pointer function that
+// returns its argument unchanged.
+func GetIdentityAddr() uintptr { return uintptr(unsafe.Pointer(C.addr_fidentity())) }
+
+// GetInvokeAddr returns the address of a function that calls the function
+// pointer passed as its first argument with its second argument and
+// returns the result, the shape every NewCallback trampoline and
+// NewVTable slot must satisfy.
+func GetInvokeAddr() uintptr { return uintptr(unsafe.Pointer(C.addr_invoke())) }
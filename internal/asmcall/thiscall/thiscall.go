@@ -0,0 +1,58 @@
+package thiscall
+
+// Call invokes the C++ method at addr using the thiscall calling
+// convention. a[0] is the `this` pointer. On Win64, thiscall, stdcall and
+// cdecl all collapse to the same register convention, so this is the same
+// shape as stdcall.Call; on every other platform this package supports
+// (Linux/macOS, amd64 and arm64), the Itanium C++ ABI makes `this` just an
+// ordinary leading argument under SysV/AAPCS, which Call0-Call6 below
+// already produce for free. Up to six uintptr arguments are passed in
+// registers, except on Win64 amd64, where only the first four are and a
+// fifth and sixth go on the stack; more than six are passed by CallN.
+func Call(addr uintptr, a ...uintptr) (uintptr, error) {
+	switch l := len(a); l {
+	case 0:
+		return Call0(addr), nil
+	case 1:
+		return Call1(addr, a[0]), nil
+	case 2:
+		return Call2(addr, a[0], a[1]), nil
+	case 3:
+		return Call3(addr, a[0], a[1], a[2]), nil
+	case 4:
+		return Call4(addr, a[0], a[1], a[2], a[3]), nil
+	case 5:
+		return Call5(addr, a[0], a[1], a[2], a[3], a[4]), nil
+	case 6:
+		return Call6(addr, a[0], a[1], a[2], a[3], a[4], a[5]), nil
+	default:
+		return CallN(addr, a), nil
+	}
+}
+
+// CallN invokes the C++ method at addr with an arbitrary number of uintptr
+// arguments (a[0] being `this`). See stdcall.CallN for the register/stack
+// layout; thiscall shares it on Win64.
+//
+// There is no hard limit on len(a); unlike Call, CallN never returns an
+// error for arity.
+func CallN(addr uintptr, a []uintptr) uintptr {
+	switch l := len(a); l {
+	case 0:
+		return Call0(addr)
+	case 1:
+		return Call1(addr, a[0])
+	case 2:
+		return Call2(addr, a[0], a[1])
+	case 3:
+		return Call3(addr, a[0], a[1], a[2])
+	case 4:
+		return Call4(addr, a[0], a[1], a[2], a[3])
+	case 5:
+		return Call5(addr, a[0], a[1], a[2], a[3], a[4])
+	case 6:
+		return Call6(addr, a[0], a[1], a[2], a[3], a[4], a[5])
+	default:
+		return callN(addr, a)
+	}
+}
@@ -0,0 +1,43 @@
+//go:build !windows
+
+package thiscall
+
+import (
+	"testing"
+
+	"github.com/lsegal/cppgo/internal/asmcall/asmcalltest"
+)
+
+func TestCallArity(t *testing.T) {
+	cases := []struct {
+		addr uintptr
+		args []uintptr
+		want uintptr
+	}{
+		{asmcalltest.GetF0Addr(), []uintptr{0}, 42},
+		{asmcalltest.GetF1Addr(), []uintptr{0, 16}, 17},
+		{asmcalltest.GetF2Addr(), []uintptr{0, 4, 2}, 2},
+		{asmcalltest.GetF3Addr(), []uintptr{0, 4, 2, 2}, 1},
+		{asmcalltest.GetF4Addr(), []uintptr{0, 16, 2, 4, 2}, 1},
+		{asmcalltest.GetF5Addr(), []uintptr{0, 99, 99, 99, 99, 12}, 12},
+	}
+	for _, c := range cases {
+		got, err := Call(c.addr, c.args...)
+		if err != nil {
+			t.Fatalf("Call(%v): %v", c.args, err)
+		}
+		if got != c.want {
+			t.Errorf("Call(%v) = %d, want %d", c.args, got, c.want)
+		}
+	}
+}
+
+func TestCallNSpill(t *testing.T) {
+	// this + 7 args is more than SysV AMD64's six integer registers, so on
+	// amd64 it forces CallN's stack-spill path rather than Call0-Call6; on
+	// arm64 it still goes through callN, with all eight in X0-X7.
+	got := CallN(asmcalltest.GetFSumAddr(), []uintptr{0, 1, 2, 3, 4, 5, 6, 7})
+	if want := uintptr(1 + 2 + 3 + 4 + 5 + 6 + 7); got != want {
+		t.Errorf("CallN = %d, want %d", got, want)
+	}
+}
@@ -0,0 +1,53 @@
+package thiscall
+
+import (
+	"math"
+	"unsafe"
+)
+
+// Kind identifies how an Arg or return value should be marshalled: as an
+// integer/pointer in a general-purpose register, or as a float in an XMM
+// register.
+type Kind int
+
+const (
+	Int Kind = iota
+	Float32
+	Float64
+	Ptr
+)
+
+// Arg is one argument to CallTyped. Build one with IntArg, PtrArg, F32Arg or
+// F64Arg. Floats are stored as their raw IEEE-754 bits, not converted, so a
+// Float32 arg ends up in the low 32 bits of its XMM register exactly as a C
+// `float` parameter expects.
+type Arg struct {
+	Kind Kind
+	i    uintptr
+	bits uint64
+}
+
+// IntArg returns an Arg carrying an integer/uintptr value.
+func IntArg(v uintptr) Arg { return Arg{Kind: Int, i: v} }
+
+// PtrArg returns an Arg carrying a pointer value.
+func PtrArg(v unsafe.Pointer) Arg { return Arg{Kind: Ptr, i: uintptr(v)} }
+
+// F32Arg returns an Arg carrying a float32 value, passed in an XMM register.
+func F32Arg(v float32) Arg { return Arg{Kind: Float32, bits: uint64(math.Float32bits(v))} }
+
+// F64Arg returns an Arg carrying a float64 value, passed in an XMM register.
+func F64Arg(v float64) Arg { return Arg{Kind: Float64, bits: math.Float64bits(v)} }
+
+// Ret is the typed result of a CallTyped, holding either an integer/pointer
+// value read from RAX or a float read from XMM0, depending on the Kind
+// passed to CallTyped.
+type Ret struct {
+	Kind Kind
+	i    uintptr
+	bits uint64
+}
+
+func (r Ret) Uintptr() uintptr { return r.i }
+func (r Ret) Float32() float32 { return math.Float32frombits(uint32(r.bits)) }
+func (r Ret) Float64() float64 { return math.Float64frombits(r.bits) }
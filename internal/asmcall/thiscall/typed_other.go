@@ -0,0 +1,70 @@
+//go:build !windows
+
+package thiscall
+
+import "fmt"
+
+// On non-Windows platforms a C++ member function is just a free function
+// with `this` as an ordinary first argument (the Itanium C++ ABI), so
+// thiscall's typed marshalling is identical to the SysV AMD64 scheme: an
+// independent register-class counter for integers/pointers and one for
+// floats, each spilling to the stack in original order once its six (or
+// eight) registers are exhausted.
+const (
+	maxIntRegs   = 6 // RDI, RSI, RDX, RCX, R8, R9
+	maxFloatRegs = 8 // XMM0-XMM7
+)
+
+// CallTyped invokes the C++ method at addr (a[0] being `this`), marshalling
+// each Arg into the integer or SSE register class the SysV AMD64 ABI
+// assigns it and spilling whatever overflows onto the stack in original
+// order. retKind selects whether the result is read from RAX (Int/Ptr) or
+// XMM0 (Float32/Float64).
+//
+// callTyped has an amd64 body only (typed_amd64.s); there is none for
+// arm64, so a program that calls CallTyped does not link on linux/arm64 or
+// darwin/arm64.
+func CallTyped(addr uintptr, args []Arg, retKind Kind) (Ret, error) {
+	var ints [maxIntRegs]uintptr
+	var floats [maxFloatRegs]uint64
+	nints, nfloats := 0, 0
+	var stack []uintptr
+
+	for _, a := range args {
+		switch a.Kind {
+		case Int, Ptr:
+			if nints < maxIntRegs {
+				ints[nints] = a.i
+				nints++
+			} else {
+				stack = append(stack, a.i)
+			}
+		case Float32, Float64:
+			if nfloats < maxFloatRegs {
+				floats[nfloats] = a.bits
+				nfloats++
+			} else {
+				stack = append(stack, uintptr(a.bits))
+			}
+		default:
+			return Ret{}, fmt.Errorf("thiscall: unknown arg kind %d", a.Kind)
+		}
+	}
+
+	var stackPtr *uintptr
+	if len(stack) > 0 {
+		stackPtr = &stack[0]
+	}
+
+	retI, retF := callTyped(addr, &ints[0], nints, &floats[0], nfloats, stackPtr, len(stack))
+	if retKind == Float32 || retKind == Float64 {
+		return Ret{Kind: retKind, bits: retF}, nil
+	}
+	return Ret{Kind: retKind, i: retI}, nil
+}
+
+// callTyped is the trampoline backing CallTyped. ints and floats hold the
+// already-classified register-class arguments (nints/nfloats long), and
+// stack holds whatever overflowed both, in original argument order.
+//go:noescape
+func callTyped(addr uintptr, ints *uintptr, nints int, floats *uint64, nfloats int, stack *uintptr, nstack int) (retInt uintptr, retFloatBits uint64)
@@ -0,0 +1,70 @@
+package thiscall
+
+import "fmt"
+
+// maxSlots is the number of argument "slots" Win64 assigns positionally:
+// slot N is RCX/RDX/R8/R9 if the Nth argument (this included) is integral,
+// or XMM0-XMM3 if it is floating point — the two register files share one
+// position counter instead of being numbered independently.
+const maxSlots = 4
+
+// CallTyped invokes the C++ method at addr, marshalling each Arg (a[0]
+// being `this`) into the register or stack slot its position in the
+// argument list assigns it under the Win64 convention, and spilling
+// whatever overflows the first four slots onto the stack (after the
+// mandatory 32-byte shadow space) in original order. retKind selects
+// whether the result is read from RAX (Int/Ptr) or XMM0 (Float32/Float64).
+//
+// callTyped has a windows/amd64 body only (typed_windows_amd64.s); a
+// windows/arm64 program that calls CallTyped does not link.
+func CallTyped(addr uintptr, args []Arg, retKind Kind) (Ret, error) {
+	var slots [maxSlots]uint64
+	var isFloat [maxSlots]bool
+	nslots := 0
+	var stack []uintptr
+
+	for _, a := range args {
+		var bits uint64
+		switch a.Kind {
+		case Int, Ptr:
+			bits = uint64(a.i)
+		case Float32, Float64:
+			bits = a.bits
+		default:
+			return Ret{}, fmt.Errorf("thiscall: unknown arg kind %d", a.Kind)
+		}
+		if nslots < maxSlots {
+			slots[nslots] = bits
+			isFloat[nslots] = a.Kind == Float32 || a.Kind == Float64
+			nslots++
+		} else {
+			stack = append(stack, uintptr(bits))
+		}
+	}
+
+	var floatMask uint64
+	for i := 0; i < nslots; i++ {
+		if isFloat[i] {
+			floatMask |= 1 << uint(i)
+		}
+	}
+
+	var stackPtr *uintptr
+	if len(stack) > 0 {
+		stackPtr = &stack[0]
+	}
+
+	retI, retF := callTyped(addr, &slots[0], nslots, floatMask, stackPtr, len(stack))
+	if retKind == Float32 || retKind == Float64 {
+		return Ret{Kind: retKind, bits: retF}, nil
+	}
+	return Ret{Kind: retKind, i: retI}, nil
+}
+
+// callTyped is the trampoline backing CallTyped. slots holds the raw bits
+// of the first nslots arguments in position order; floatMask has bit i set
+// if slots[i] is a float that belongs in XMMi rather than its integer
+// register. stack holds whatever overflowed the four slots, in original
+// argument order.
+//go:noescape
+func callTyped(addr uintptr, slots *uint64, nslots int, floatMask uint64, stack *uintptr, nstack int) (retInt uintptr, retFloatBits uint64)
@@ -0,0 +1,22 @@
+//go:build !windows
+
+package cppgo
+
+import "syscall"
+
+// allocExecPage reserves size bytes of memory writable but not yet
+// executable (W^X), so writeTrampoline can fill it in before protectExec
+// flips it over to read+exec.
+func allocExecPage(size int) ([]byte, error) {
+	return syscall.Mmap(-1, 0, size, syscall.PROT_READ|syscall.PROT_WRITE, syscall.MAP_PRIVATE|syscall.MAP_ANON)
+}
+
+// protectExec drops write permission and adds exec permission, so the
+// trampoline is never simultaneously writable and executable.
+func protectExec(page []byte) error {
+	return syscall.Mprotect(page, syscall.PROT_READ|syscall.PROT_EXEC)
+}
+
+func freeExecPage(page []byte) error {
+	return syscall.Munmap(page)
+}
@@ -0,0 +1,55 @@
+package cppgo
+
+import (
+	"syscall"
+	"unsafe"
+)
+
+// VirtualAlloc/VirtualProtect/VirtualFree aren't in the standard syscall
+// package on Windows (only golang.org/x/sys/windows has them), and this
+// repo has no go.mod to pull in that dependency, so we call kernel32.dll
+// directly the way the rest of the stdlib-only Windows code here does.
+var (
+	modkernel32        = syscall.NewLazyDLL("kernel32.dll")
+	procVirtualAlloc   = modkernel32.NewProc("VirtualAlloc")
+	procVirtualProtect = modkernel32.NewProc("VirtualProtect")
+	procVirtualFree    = modkernel32.NewProc("VirtualFree")
+)
+
+const (
+	memCommit     = 0x00001000
+	memReserve    = 0x00002000
+	memRelease    = 0x00008000
+	pageReadWrite = 0x04
+	pageExecRead  = 0x20
+)
+
+// allocExecPage reserves size bytes of memory writable but not yet
+// executable (W^X), so writeTrampoline can fill it in before protectExec
+// flips it over to read+exec.
+func allocExecPage(size int) ([]byte, error) {
+	addr, _, err := procVirtualAlloc.Call(0, uintptr(size), memCommit|memReserve, pageReadWrite)
+	if addr == 0 {
+		return nil, err
+	}
+	return unsafe.Slice((*byte)(unsafe.Pointer(addr)), size), nil
+}
+
+// protectExec drops write permission and adds exec permission, so the
+// trampoline is never simultaneously writable and executable.
+func protectExec(page []byte) error {
+	var old uint32
+	ok, _, err := procVirtualProtect.Call(uintptr(unsafe.Pointer(&page[0])), uintptr(len(page)), pageExecRead, uintptr(unsafe.Pointer(&old)))
+	if ok == 0 {
+		return err
+	}
+	return nil
+}
+
+func freeExecPage(page []byte) error {
+	ok, _, err := procVirtualFree.Call(uintptr(unsafe.Pointer(&page[0])), 0, memRelease)
+	if ok == 0 {
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,73 @@
+//go:build amd64
+
+package cppgo
+
+import (
+	"reflect"
+	"sync"
+	"unsafe"
+)
+
+// vtableMu and vtablePins keep the backing arrays NewVTable builds alive:
+// only a raw uintptr is handed back to C++, so the Go runtime has no other
+// reason to keep them reachable.
+var (
+	vtableMu   sync.Mutex
+	vtablePins [][]uintptr
+)
+
+// NewVTable builds a synthetic C++ vtable for iface, a Go value whose
+// exported methods become the vtable's slots in the order reflect lists
+// them (sorted by method name, not declaration order), and returns it as a raw uintptr suitable for storing in the first word of an
+// object handed to C++ code expecting an abstract-base pointer.
+//
+// Each slot is a NewCallback trampoline wrapping the corresponding method,
+// adapted to accept the `this` pointer C++ always passes as the hidden
+// first argument (and ignore it, since the bound Go receiver already
+// identifies the target). The trampolines, and therefore iface, are never
+// released; NewVTable is meant for long-lived sinks such as comparators and
+// event listeners, not short-lived objects.
+//
+// Like NewCallback, this only produces slots callable under the SysV cdecl
+// convention, i.e. an Itanium C++ ABI vtable (Linux/macOS). A Win64 COM
+// vtable, whose slots expect thiscall/stdcall register placement, is not
+// supported.
+func NewVTable(iface interface{}) uintptr {
+	v := reflect.ValueOf(iface)
+	t := v.Type()
+
+	slots := make([]uintptr, t.NumMethod())
+	for i := range slots {
+		slots[i] = newVTableSlot(v.Method(i))
+	}
+
+	vtableMu.Lock()
+	vtablePins = append(vtablePins, slots)
+	vtableMu.Unlock()
+
+	return uintptr(unsafe.Pointer(&slots[0]))
+}
+
+// newVTableSlot wraps a bound method m in an adapter that takes an extra
+// leading `this uintptr` (discarded) and returns the address of a
+// NewCallback trampoline for that adapter.
+func newVTableSlot(m reflect.Value) uintptr {
+	mt := m.Type()
+
+	in := make([]reflect.Type, mt.NumIn()+1)
+	in[0] = reflect.TypeOf(uintptr(0))
+	for i := 0; i < mt.NumIn(); i++ {
+		in[i+1] = mt.In(i)
+	}
+	out := make([]reflect.Type, mt.NumOut())
+	for i := range out {
+		out[i] = mt.Out(i)
+	}
+
+	adapter := reflect.MakeFunc(reflect.FuncOf(in, out, mt.IsVariadic()), func(args []reflect.Value) []reflect.Value {
+		return m.Call(args[1:])
+	})
+
+	addr, _ := NewCallback(adapter.Interface())
+	return addr
+}